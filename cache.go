@@ -3,101 +3,207 @@ package heapcache
 import (
 	"container/heap"
 	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
 type (
-	// Item is something that able to be added to cache.
-	Item interface {
-		// CacheKey return key of item in cache. It may be any key type (see https://golang.org/ref/spec#KeyType)
-		CacheKey() interface{}
-		// CacheLess determines priority if items in cache. Items with less priority will be evicted first.
-		CacheLess(interface{}) bool
-	}
-
-	itemsMap map[interface{}]*wrapper
-
 	// wrapper is a cache item wrapper
-	wrapper struct {
+	wrapper[K comparable, V any] struct {
+		key   K
+		value V
+
+		// index is only meaningful under the heap policy, see heap.go.
 		index int
-		key   interface{}
-		item  Item
+
+		// prev, next and visited are only meaningful under the LRU and SIEVE
+		// policies, see evictor.go.
+		prev, next *wrapper[K, V]
+		visited    bool
+
+		// expIndex and expiresAt are only meaningful when TTL support is in
+		// use, see ttl.go. expIndex is the wrapper's position in expHeap, or
+		// -1 if the wrapper carries no expiry.
+		expIndex  int
+		expiresAt time.Time
+
+		// weight is only meaningful for Caches created with NewWithWeight, see weight.go.
+		weight int64
 	}
 
+	// Option configures optional Cache behaviour at construction time.
+	Option[K comparable, V any] func(*Cache[K, V])
+
 	// Cache is a cache abstraction
-	Cache struct {
+	Cache[K comparable, V any] struct {
 		capacity int
-		heap     itemsHeap
-		items    itemsMap
+		evictor  evictor[K, V]
+		items    map[K]*wrapper[K, V]
 		mutex    sync.RWMutex
+
+		defaultTTL time.Duration
+		expHeap    *expirationHeap[K, V]
+		timerCh    chan time.Duration
+		stopCh     chan struct{}
+		monitorOn  sync.Once
+		monitorRun bool
+		monitorWG  sync.WaitGroup
+
+		listenersMu        sync.Mutex
+		nextListenerID     uint64
+		insertionListeners map[uint64]*insertionListener[K, V]
+		evictionListeners  map[uint64]*evictionListener[K, V]
+
+		sf singleflight.Group
+
+		weigher     Weigher[V]
+		maxWeight   int64
+		totalWeight int64
 	}
 )
 
-// New creates a new Cache instance
+// New creates a new Cache instance. less determines priority under the
+// default heap policy: items for which less reports true are evicted first.
+// Pass WithLRUPolicy or WithSIEVEPolicy to evict by recency instead, in which
+// case less is ignored.
 // Capacity allowed to be zero. In this case cache becomes dummy, 'Add' do nothing and items can't be stored in.
-func New(capacity int) *Cache {
+func New[K comparable, V any](capacity int, less Less[V], opts ...Option[K, V]) *Cache[K, V] {
 	if capacity < 0 {
 		capacity = 0
 	}
 
-	return &Cache{
+	c := &Cache[K, V]{
 		capacity: capacity,
-		heap:     make(itemsHeap, 0, capacity),
-		items:    make(itemsMap, capacity),
+		items:    make(map[K]*wrapper[K, V], capacity),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.evictor == nil {
+		c.evictor = newHeapEvictor[K, V](capacity, less)
 	}
+
+	return c
 }
 
 // Capacity returns capacity of cache
-func (c *Cache) Capacity() int {
+func (c *Cache[K, V]) Capacity() int {
 	return c.capacity
 }
 
-// Add adds a `value` into a cache. If `key` already exists, `value` and `priority` will be overwritten.
-// `key` must be a KeyType (see https://golang.org/ref/spec#KeyType)
-func (c *Cache) Add(items ...Item) {
+// Add adds a `value` into a cache under `key`. If `key` already exists, `value` will be overwritten.
+// If the Cache was built with WithTTL, the default TTL applies to added items.
+// On a Cache built with NewWithWeight, an item whose own weight exceeds MaxWeight is silently
+// refused; use TryAdd to detect that case.
+func (c *Cache[K, V]) Add(key K, value V) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	for _, item := range items {
-		c.addItem(item)
-	}
+	_ = c.addItem(key, value, c.defaultTTL)
+}
+
+// TryAdd behaves like Add, but reports ErrItemTooLarge instead of silently
+// refusing insertion when a Cache built with NewWithWeight can never fit the item.
+func (c *Cache[K, V]) TryAdd(key K, value V) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.addItem(key, value, c.defaultTTL)
+}
+
+// AddWithTTL adds a `value` into a cache under `key` with its own expiration, overriding any default TTL.
+// A zero or negative ttl means the item never expires.
+func (c *Cache[K, V]) AddWithTTL(key K, value V, ttl time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	_ = c.addItem(key, value, ttl)
 }
 
-func (c *Cache) addItem(newItem Item) {
+func (c *Cache[K, V]) addItem(key K, value V, ttl time.Duration) error {
+	if c.weigher != nil {
+		return c.addItemWeighted(key, value, ttl)
+	}
+
 	if c.capacity == 0 {
-		return
+		return nil
 	}
 
-	key := newItem.CacheKey()
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
 
 	if item, ok := c.items[key]; ok { // already exists
-		c.items[key].item = newItem
-		heap.Fix(&c.heap, item.index)
-		return
+		item.value = value
+		c.evictor.Touch(item)
+		c.setExpiry(item, expiresAt)
+		c.fireInsertion(key, value)
+		return nil
 	}
 
 	if len(c.items) >= c.capacity {
-		c.evict(1)
+		c.evict(1, ReasonCapacity)
 	}
 
-	w := wrapper{key: key, item: newItem}
+	w := &wrapper[K, V]{key: key, value: value, expIndex: -1}
 
-	heap.Push(&c.heap, &w)
-	c.items[w.key] = &w
+	c.evictor.Insert(w)
+	c.items[w.key] = w
+	c.setExpiry(w, expiresAt)
+	c.fireInsertion(key, value)
+	return nil
 }
 
-// Get gets a value by `key`
-func (c *Cache) Get(key interface{}) (interface{}, bool) {
-	c.mutex.RLock()
-	defer c.mutex.RUnlock()
+// Get gets a value by `key`. Under the LRU and SIEVE policies, a successful
+// Get counts as an access and affects what gets evicted next, so it takes
+// the same lock as a write; under the heap policy, access doesn't affect
+// eviction order and Get only takes a read lock.
+func (c *Cache[K, V]) Get(key K) (value V, ok bool) {
+	if !c.evictor.TouchOnRead() {
+		c.mutex.RLock()
+		item, ok := c.items[key]
+		if !ok {
+			c.mutex.RUnlock()
+			return value, false
+		}
+		if !item.expired() {
+			value = item.value
+			c.mutex.RUnlock()
+			return value, true
+		}
+		c.mutex.RUnlock()
+
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		if item, ok = c.items[key]; ok && item.expired() {
+			c.removeWrapper(item, ReasonExpired)
+		}
+		return value, false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	item, ok := c.items[key]
+	if !ok {
+		return value, false
+	}
 
-	if item, ok := c.items[key]; ok {
-		return item.item, true
+	if item.expired() {
+		c.removeWrapper(item, ReasonExpired)
+		return value, false
 	}
-	return nil, false
+
+	c.evictor.Touch(item)
+	return item.value, true
 }
 
 // All checks if ALL `keys` exists
-func (c *Cache) All(keys ...interface{}) bool {
+func (c *Cache[K, V]) All(keys ...K) bool {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
@@ -110,7 +216,7 @@ func (c *Cache) All(keys ...interface{}) bool {
 }
 
 // Any checks if ANY of `keys` exists
-func (c *Cache) Any(keys ...interface{}) bool {
+func (c *Cache[K, V]) Any(keys ...K) bool {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
@@ -124,22 +230,34 @@ func (c *Cache) Any(keys ...interface{}) bool {
 
 // Remove removes values by keys
 // Returns number of actually removed items
-func (c *Cache) Remove(keys ...interface{}) (removed int) {
+func (c *Cache[K, V]) Remove(keys ...K) (removed int) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
 	for _, key := range keys {
 		if item, ok := c.items[key]; ok {
-			delete(c.items, key)
-			heap.Remove(&c.heap, item.index)
+			c.removeWrapper(item, ReasonManual)
 			removed++
 		}
 	}
 	return
 }
 
+// removeWrapper removes w from every structure that indexes it and fires an eviction event.
+// caller must keep write lock
+func (c *Cache[K, V]) removeWrapper(w *wrapper[K, V], reason EvictionReason) {
+	delete(c.items, w.key)
+	c.evictor.Remove(w)
+	c.totalWeight -= w.weight
+	if c.expHeap != nil && w.expIndex >= 0 {
+		heap.Remove(c.expHeap, w.expIndex)
+		c.notifyTimer()
+	}
+	c.fireEviction(w.key, w.value, reason)
+}
+
 // Len returns a number of items in cache
-func (c *Cache) Len() int {
+func (c *Cache[K, V]) Len() int {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
@@ -147,28 +265,45 @@ func (c *Cache) Len() int {
 }
 
 // Purge removes all items
-func (c *Cache) Purge() {
+func (c *Cache[K, V]) Purge() {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	c.heap = make(itemsHeap, 0, c.capacity)
-	c.items = make(itemsMap, c.capacity)
+	for _, w := range c.items {
+		c.fireEviction(w.key, w.value, ReasonPurge)
+	}
+
+	for c.evictor.Len() > 0 {
+		c.evictor.EvictOne()
+	}
+	c.items = make(map[K]*wrapper[K, V], c.capacity)
+	c.totalWeight = 0
+	if c.expHeap != nil {
+		c.expHeap = newExpirationHeap[K, V](c.capacity)
+		c.notifyTimer()
+	}
 }
 
 // Evict removes `count` elements with lowest priority.
 // TODO Is this useful ever?
-func (c *Cache) Evict(count int) int {
+func (c *Cache[K, V]) Evict(count int) int {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	return c.evict(count)
+	return c.evict(count, ReasonCapacity)
 }
 
 // caller must keep write lock
-func (c *Cache) evict(count int) (evicted int) {
-	for count > 0 && c.heap.Len() > 0 {
-		item := heap.Pop(&c.heap)
-		delete(c.items, item.(*wrapper).key)
+func (c *Cache[K, V]) evict(count int, reason EvictionReason) (evicted int) {
+	for count > 0 && c.evictor.Len() > 0 {
+		w := c.evictor.EvictOne()
+		delete(c.items, w.key)
+		c.totalWeight -= w.weight
+		if c.expHeap != nil && w.expIndex >= 0 {
+			heap.Remove(c.expHeap, w.expIndex)
+			c.notifyTimer()
+		}
+		c.fireEviction(w.key, w.value, reason)
 		count--
 		evicted++
 	}
@@ -178,14 +313,14 @@ func (c *Cache) evict(count int) (evicted int) {
 // ChangeCapacity change cache capacity by `delta`.
 // If `delta` is positive cache capacity will be expanded, if `delta` is negative, it will be shrunk.
 // Redundant items will be evicted.
-func (c *Cache) ChangeCapacity(delta int) {
+func (c *Cache[K, V]) ChangeCapacity(delta int) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
 	c.setCapacity(c.capacity + delta)
 }
 
-func (c *Cache) setCapacity(capacity int) {
+func (c *Cache[K, V]) setCapacity(capacity int) {
 	if capacity == c.capacity {
 		return
 	}
@@ -196,7 +331,7 @@ func (c *Cache) setCapacity(capacity int) {
 
 	redundant := len(c.items) - capacity
 	if redundant > 0 {
-		c.evict(redundant)
+		c.evict(redundant, ReasonCapacityShrink)
 	}
 
 	c.capacity = capacity
@@ -205,7 +340,7 @@ func (c *Cache) setCapacity(capacity int) {
 // SetCapacity sets cache capacity.
 // Redundant items will be evicted.
 // Capacity will never be less than zero.
-func (c *Cache) SetCapacity(capacity int) {
+func (c *Cache[K, V]) SetCapacity(capacity int) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
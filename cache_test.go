@@ -14,12 +14,12 @@ type cacheItem struct {
 	Priority int
 }
 
-func testLess(a, b interface{}) bool {
-	return a.(*cacheItem).Priority < b.(*cacheItem).Priority
+func testLess(a, b *cacheItem) bool {
+	return a.Priority < b.Priority
 }
 
 func TestCache_Add(t *testing.T) {
-	c := New(10, testLess)
+	c := New[string, *cacheItem](10, testLess)
 
 	foo1 := &cacheItem{"bar1", 1}
 	foo2 := &cacheItem{"bar2", 2}
@@ -42,18 +42,18 @@ func TestCache_Add(t *testing.T) {
 	{
 		val, ok := c.Get("foo1")
 		assert.True(t, ok)
-		assert.Equal(t, "bar123", val.(*cacheItem).Value)
+		assert.Equal(t, "bar123", val.Value)
 	}
 }
 
 func TestCache_Get(t *testing.T) {
-	c := New(10, testLess)
+	c := New[string, *cacheItem](10, testLess)
 
 	c.Add("foo1", &cacheItem{"bar1", 1})
 	{
 		val, ok := c.Get("foo1")
 		assert.True(t, ok)
-		assert.Equal(t, "bar1", val.(*cacheItem).Value)
+		assert.Equal(t, "bar1", val.Value)
 	}
 	{
 		val, ok := c.Get("foo2")
@@ -63,7 +63,7 @@ func TestCache_Get(t *testing.T) {
 }
 
 func TestCache_Len(t *testing.T) {
-	c := New(10, testLess)
+	c := New[string, *cacheItem](10, testLess)
 
 	c.Add("foo1", &cacheItem{"bar1", 1})
 
@@ -75,7 +75,7 @@ func TestCache_evict(t *testing.T) {
 	capacity := 50
 	n := 100
 
-	c := New(capacity, testLess)
+	c := New[int, *cacheItem](capacity, testLess)
 
 	for i = 0; i < n; i++ {
 		v := strconv.FormatInt(int64(i), 10)
@@ -94,7 +94,7 @@ func TestCache_evict(t *testing.T) {
 }
 
 func TestCache_Remove(t *testing.T) {
-	c := New(10, testLess)
+	c := New[string, *cacheItem](10, testLess)
 
 	c.Add("foo1", &cacheItem{"bar1", 1})
 	c.Add("foo2", &cacheItem{"bar2", 2})
@@ -112,7 +112,7 @@ func TestCache_Remove(t *testing.T) {
 }
 
 func TestCache_All(t *testing.T) {
-	c := New(10, testLess)
+	c := New[string, *cacheItem](10, testLess)
 
 	c.Add("foo1", &cacheItem{"bar1", 1})
 	c.Add("foo2", &cacheItem{"bar2", 1})
@@ -124,7 +124,7 @@ func TestCache_All(t *testing.T) {
 }
 
 func TestCache_Any(t *testing.T) {
-	c := New(10, testLess)
+	c := New[string, *cacheItem](10, testLess)
 
 	c.Add("foo1", &cacheItem{"bar1", 1})
 	c.Add("foo2", &cacheItem{"bar2", 1})
@@ -137,7 +137,7 @@ func TestCache_Any(t *testing.T) {
 }
 
 func TestCache_Priority(t *testing.T) {
-	c := New(3, testLess)
+	c := New[string, *cacheItem](3, testLess)
 
 	c.Add("foo1", &cacheItem{"bar1", 10})
 	c.Add("foo2", &cacheItem{"bar2", 20})
@@ -164,7 +164,7 @@ func TestCache_Priority(t *testing.T) {
 }
 
 func TestCache_ZeroCapacity(t *testing.T) {
-	c := New(0, testLess)
+	c := New[string, *cacheItem](0, testLess)
 
 	c.Add("foo", &cacheItem{"bar", 1})
 	c.Add("foo", &cacheItem{"bar", 1})
@@ -172,7 +172,7 @@ func TestCache_ZeroCapacity(t *testing.T) {
 }
 
 func TestCache_Purge(t *testing.T) {
-	c := New(3, testLess)
+	c := New[string, *cacheItem](3, testLess)
 
 	c.Add("foo1", &cacheItem{"bar1", 1})
 	c.Add("foo2", &cacheItem{"bar2", 1})
@@ -185,7 +185,7 @@ func TestCache_Purge(t *testing.T) {
 }
 
 func TestCache_Evict(t *testing.T) {
-	c := New(3, testLess)
+	c := New[string, *cacheItem](3, testLess)
 
 	c.Add("foo1", &cacheItem{"bar1", 1})
 	c.Add("foo2", &cacheItem{"bar2", 2})
@@ -212,12 +212,12 @@ func TestCache_Evict(t *testing.T) {
 }
 
 func TestCache_Capacity(t *testing.T) {
-	c := New(3, testLess)
+	c := New[string, *cacheItem](3, testLess)
 	assert.Equal(t, 3, c.Capacity())
 }
 
 func TestCache_ChangeCapacity(t *testing.T) {
-	c := New(3, testLess)
+	c := New[string, *cacheItem](3, testLess)
 
 	c.Add("foo1", &cacheItem{"bar1", 1})
 	c.Add("foo2", &cacheItem{"bar2", 2})
@@ -264,19 +264,19 @@ func TestCache_ChangeCapacity(t *testing.T) {
 }
 
 func TestCache_SetCapacityUnderflow(t *testing.T) {
-	c := New(3, testLess)
+	c := New[string, *cacheItem](3, testLess)
 	c.SetCapacity(-5)
 	assert.Equal(t, 0, c.Capacity())
 }
 
 func TestCache_ChangeCapacityUnderflow(t *testing.T) {
-	c := New(3, testLess)
+	c := New[string, *cacheItem](3, testLess)
 	c.ChangeCapacity(-5)
 	assert.Equal(t, 0, c.Capacity())
 }
 
 func TestCache_SetCapacity(t *testing.T) {
-	c := New(3, testLess)
+	c := New[string, *cacheItem](3, testLess)
 
 	c.Add("foo1", &cacheItem{"bar1", 1})
 	c.Add("foo2", &cacheItem{"bar2", 2})
@@ -315,8 +315,71 @@ func TestCache_SetCapacity(t *testing.T) {
 	assert.False(t, c.All("foo1", "foo2"))
 }
 
+func TestCache_AddWithTTL(t *testing.T) {
+	c := New[string, *cacheItem](10, testLess)
+	defer c.Stop()
+
+	c.AddWithTTL("foo1", &cacheItem{"bar1", 1}, 20*time.Millisecond)
+
+	{
+		val, ok := c.Get("foo1")
+		assert.True(t, ok)
+		assert.Equal(t, "bar1", val.Value)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	{
+		_, ok := c.Get("foo1")
+		assert.False(t, ok)
+	}
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestCache_WithTTL(t *testing.T) {
+	c := New[string, *cacheItem](10, testLess, WithTTL[string, *cacheItem](20*time.Millisecond))
+	defer c.Stop()
+
+	c.Add("foo1", &cacheItem{"bar1", 1})
+	c.AddWithTTL("foo2", &cacheItem{"bar2", 2}, 0)
+
+	time.Sleep(100 * time.Millisecond)
+
+	_, ok := c.Get("foo1")
+	assert.False(t, ok)
+
+	// foo2 opted out of the default TTL and must survive
+	assert.True(t, c.All("foo2"))
+}
+
+func TestCache_WithTTL_AlreadyDueHeadDoesNotStallMonitor(t *testing.T) {
+	c := New[string, *cacheItem](10, testLess)
+	defer c.Stop()
+
+	// foo1's expiry is already in the past by the time the monitor picks it
+	// up as expHeap's head; a later, normal-TTL item must still get swept.
+	c.AddWithTTL("foo1", &cacheItem{"bar1", 1}, 1*time.Nanosecond)
+	time.Sleep(20 * time.Millisecond)
+
+	c.AddWithTTL("foo2", &cacheItem{"bar2", 2}, 20*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestCache_Stop(t *testing.T) {
+	c := New[string, *cacheItem](10, testLess, WithTTL[string, *cacheItem](10*time.Millisecond))
+
+	c.Add("foo1", &cacheItem{"bar1", 1})
+	c.Stop()
+
+	// Stop must be idempotent and must not evict already-cached items.
+	c.Stop()
+	assert.True(t, c.All("foo1"))
+}
+
 func BenchmarkCache_Add(b *testing.B) {
-	c := New(b.N, testLess)
+	c := New[int, *cacheItem](b.N, testLess)
 
 	item := &cacheItem{"", 0}
 	for n := 0; n < b.N; n++ {
@@ -325,7 +388,7 @@ func BenchmarkCache_Add(b *testing.B) {
 }
 
 func BenchmarkCache_AddWithEvictHalf(b *testing.B) {
-	c := New(b.N/2, testLess)
+	c := New[int, *cacheItem](b.N/2, testLess)
 
 	item := &cacheItem{"", 0}
 	for n := 0; n < b.N; n++ {
@@ -334,7 +397,7 @@ func BenchmarkCache_AddWithEvictHalf(b *testing.B) {
 }
 
 func BenchmarkCache_Get(b *testing.B) {
-	c := New(b.N, testLess)
+	c := New[int, *cacheItem](b.N, testLess)
 
 	for n := 0; n < b.N; n++ {
 		c.Get(n)
@@ -342,18 +405,8 @@ func BenchmarkCache_Get(b *testing.B) {
 }
 
 func Example() {
-	type Foo struct {
-		Value     int
-		Timestamp time.Time
-	}
-
-	cache := New(10, func(a, b interface{}) bool {
-		return a.(*Foo).Timestamp.Before(b.(*Foo).Timestamp)
-	})
-
-	item1 := Foo{10, time.Now()}
-	item2 := Foo{20, time.Now().Add(time.Second)}
+	cache := New[string, *cacheItem](10, testLess)
 
-	cache.Add("one", &item1)
-	cache.Add("two", &item2)
+	cache.Add("one", &cacheItem{"10", 1})
+	cache.Add("two", &cacheItem{"20", 2})
 }
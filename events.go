@@ -0,0 +1,146 @@
+package heapcache
+
+// listenerQueueSize bounds how many pending events a single listener can
+// queue up before new events for it are dropped, so a slow listener can
+// never stall the cache itself.
+const listenerQueueSize = 64
+
+// EvictionReason describes why an item left the cache.
+type EvictionReason int
+
+const (
+	// ReasonCapacity means the item was evicted to make room for a new or
+	// grown one, or by an explicit call to Evict.
+	ReasonCapacity EvictionReason = iota
+	// ReasonExpired means the item's TTL passed.
+	ReasonExpired
+	// ReasonManual means the item was removed via Remove.
+	ReasonManual
+	// ReasonPurge means the item was removed via Purge.
+	ReasonPurge
+	// ReasonCapacityShrink means the item was evicted because SetCapacity/ChangeCapacity shrunk the cache.
+	ReasonCapacityShrink
+)
+
+// listenerWorker runs queued callbacks for a single listener on its own
+// goroutine, decoupled from the cache lock.
+type listenerWorker struct {
+	queue chan func()
+	stop  chan struct{}
+}
+
+func newListenerWorker() *listenerWorker {
+	w := &listenerWorker{
+		queue: make(chan func(), listenerQueueSize),
+		stop:  make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+func (w *listenerWorker) run() {
+	for {
+		select {
+		case fn := <-w.queue:
+			fn()
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// dispatch queues fn for asynchronous delivery. If the listener is falling
+// behind, the event is dropped rather than blocking the caller.
+func (w *listenerWorker) dispatch(fn func()) {
+	select {
+	case w.queue <- fn:
+	default:
+	}
+}
+
+func (w *listenerWorker) close() {
+	close(w.stop)
+}
+
+type insertionListener[K comparable, V any] struct {
+	fn func(key K, value V)
+	*listenerWorker
+}
+
+type evictionListener[K comparable, V any] struct {
+	fn func(key K, value V, reason EvictionReason)
+	*listenerWorker
+}
+
+// OnInsertion subscribes fn to be called whenever an item is added or
+// overwritten. It returns an id that can later be passed to RemoveListener.
+func (c *Cache[K, V]) OnInsertion(fn func(key K, value V)) (id uint64) {
+	c.listenersMu.Lock()
+	defer c.listenersMu.Unlock()
+
+	c.nextListenerID++
+	id = c.nextListenerID
+
+	if c.insertionListeners == nil {
+		c.insertionListeners = make(map[uint64]*insertionListener[K, V])
+	}
+	c.insertionListeners[id] = &insertionListener[K, V]{fn: fn, listenerWorker: newListenerWorker()}
+
+	return id
+}
+
+// OnEviction subscribes fn to be called whenever an item leaves the cache.
+// It returns an id that can later be passed to RemoveListener.
+func (c *Cache[K, V]) OnEviction(fn func(key K, value V, reason EvictionReason)) (id uint64) {
+	c.listenersMu.Lock()
+	defer c.listenersMu.Unlock()
+
+	c.nextListenerID++
+	id = c.nextListenerID
+
+	if c.evictionListeners == nil {
+		c.evictionListeners = make(map[uint64]*evictionListener[K, V])
+	}
+	c.evictionListeners[id] = &evictionListener[K, V]{fn: fn, listenerWorker: newListenerWorker()}
+
+	return id
+}
+
+// RemoveListener unsubscribes a listener previously registered via OnInsertion or OnEviction.
+func (c *Cache[K, V]) RemoveListener(id uint64) {
+	c.listenersMu.Lock()
+	defer c.listenersMu.Unlock()
+
+	if l, ok := c.insertionListeners[id]; ok {
+		l.close()
+		delete(c.insertionListeners, id)
+		return
+	}
+
+	if l, ok := c.evictionListeners[id]; ok {
+		l.close()
+		delete(c.evictionListeners, id)
+	}
+}
+
+// fireInsertion notifies every insertion listener. caller must keep write lock.
+func (c *Cache[K, V]) fireInsertion(key K, value V) {
+	c.listenersMu.Lock()
+	defer c.listenersMu.Unlock()
+
+	for _, l := range c.insertionListeners {
+		fn := l.fn
+		l.dispatch(func() { fn(key, value) })
+	}
+}
+
+// fireEviction notifies every eviction listener. caller must keep write lock.
+func (c *Cache[K, V]) fireEviction(key K, value V, reason EvictionReason) {
+	c.listenersMu.Lock()
+	defer c.listenersMu.Unlock()
+
+	for _, l := range c.evictionListeners {
+		fn := l.fn
+		l.dispatch(func() { fn(key, value, reason) })
+	}
+}
@@ -0,0 +1,120 @@
+package heapcache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_OnInsertion(t *testing.T) {
+	c := New[string, *cacheItem](10, testLess)
+
+	var mu sync.Mutex
+	var keys []string
+	c.OnInsertion(func(key string, value *cacheItem) {
+		mu.Lock()
+		defer mu.Unlock()
+		keys = append(keys, key)
+	})
+
+	c.Add("foo1", &cacheItem{"bar1", 1})
+	c.Add("foo2", &cacheItem{"bar2", 2})
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(keys) == 2
+	}, time.Second, time.Millisecond)
+}
+
+func TestCache_OnEviction(t *testing.T) {
+	c := New[string, *cacheItem](1, testLess)
+
+	var mu sync.Mutex
+	var reasons []EvictionReason
+	c.OnEviction(func(key string, value *cacheItem, reason EvictionReason) {
+		mu.Lock()
+		defer mu.Unlock()
+		reasons = append(reasons, reason)
+	})
+
+	c.Add("foo1", &cacheItem{"bar1", 1})
+	c.Add("foo2", &cacheItem{"bar2", 2}) // evicts foo1: ReasonCapacity
+
+	assert.Equal(t, 1, c.Remove("foo2")) // ReasonManual
+
+	c.Add("foo3", &cacheItem{"bar3", 3})
+	c.Purge() // ReasonPurge
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(reasons) == 3
+	}, time.Second, time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []EvictionReason{ReasonCapacity, ReasonManual, ReasonPurge}, reasons)
+}
+
+func TestCache_OnEviction_CapacityShrink(t *testing.T) {
+	c := New[string, *cacheItem](2, testLess)
+
+	var mu sync.Mutex
+	var reasons []EvictionReason
+	c.OnEviction(func(key string, value *cacheItem, reason EvictionReason) {
+		mu.Lock()
+		defer mu.Unlock()
+		reasons = append(reasons, reason)
+	})
+
+	c.Add("foo1", &cacheItem{"bar1", 1})
+	c.Add("foo2", &cacheItem{"bar2", 2})
+	c.SetCapacity(1)
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(reasons) == 1
+	}, time.Second, time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, ReasonCapacityShrink, reasons[0])
+}
+
+func TestCache_OnEviction_Expired(t *testing.T) {
+	c := New[string, *cacheItem](10, testLess)
+	defer c.Stop()
+
+	done := make(chan EvictionReason, 1)
+	c.OnEviction(func(key string, value *cacheItem, reason EvictionReason) {
+		done <- reason
+	})
+
+	c.AddWithTTL("foo1", &cacheItem{"bar1", 1}, 20*time.Millisecond)
+
+	select {
+	case reason := <-done:
+		assert.Equal(t, ReasonExpired, reason)
+	case <-time.After(time.Second):
+		t.Fatal("eviction event was never fired")
+	}
+}
+
+func TestCache_RemoveListener(t *testing.T) {
+	c := New[string, *cacheItem](10, testLess)
+
+	var called bool
+	id := c.OnInsertion(func(key string, value *cacheItem) {
+		called = true
+	})
+	c.RemoveListener(id)
+
+	c.Add("foo1", &cacheItem{"bar1", 1})
+
+	time.Sleep(20 * time.Millisecond)
+	assert.False(t, called)
+}
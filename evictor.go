@@ -0,0 +1,179 @@
+package heapcache
+
+// evictor decides which wrapper to evict next. The Cache's heap-based
+// priority eviction was the only policy until pluggable policies were
+// introduced; evictor lets it sit alongside recency-based policies like LRU
+// and SIEVE, selected at construction via the less argument to New (heap) or
+// via WithLRUPolicy / WithSIEVEPolicy.
+type evictor[K comparable, V any] interface {
+	// Insert adds a newly-created wrapper to the policy's bookkeeping.
+	Insert(w *wrapper[K, V])
+	// Touch records an access to w (a Get hit, or an overwrite by Add).
+	Touch(w *wrapper[K, V])
+	// Remove drops w from the policy's bookkeeping, e.g. on manual removal or expiry.
+	Remove(w *wrapper[K, V])
+	// EvictOne picks the next victim, removes it from the policy's bookkeeping and returns it.
+	// It must not be called when Len() == 0.
+	EvictOne() *wrapper[K, V]
+	// Len reports how many wrappers the policy currently tracks.
+	Len() int
+	// TouchOnRead reports whether a successful Get should call Touch. The
+	// heap policy's eviction order only depends on value and capacity, not
+	// access, so a plain read can stay on a read lock; LRU and SIEVE report
+	// true since they record accesses (LRU moves the item, SIEVE sets its
+	// visited bit), so Get takes the write lock before calling Touch.
+	TouchOnRead() bool
+}
+
+// recencyList is a doubly linked list of wrappers shared by the LRU and
+// SIEVE policies. It has no sentinel node; head is the most recently
+// inserted/touched end and tail is the eviction end.
+type recencyList[K comparable, V any] struct {
+	head, tail *wrapper[K, V]
+	length     int
+}
+
+func (l *recencyList[K, V]) pushFront(w *wrapper[K, V]) {
+	w.prev = nil
+	w.next = l.head
+	if l.head != nil {
+		l.head.prev = w
+	}
+	l.head = w
+	if l.tail == nil {
+		l.tail = w
+	}
+	l.length++
+}
+
+func (l *recencyList[K, V]) unlink(w *wrapper[K, V]) {
+	if w.prev != nil {
+		w.prev.next = w.next
+	} else if l.head == w {
+		l.head = w.next
+	}
+	if w.next != nil {
+		w.next.prev = w.prev
+	} else if l.tail == w {
+		l.tail = w.prev
+	}
+	w.prev, w.next = nil, nil
+	l.length--
+}
+
+func (l *recencyList[K, V]) moveToFront(w *wrapper[K, V]) {
+	if l.head == w {
+		return
+	}
+	l.unlink(w)
+	l.pushFront(w)
+}
+
+// lruEvictor implements the least-recently-used policy: insertion and every
+// access move the wrapper to the front of the list, eviction takes from the tail.
+type lruEvictor[K comparable, V any] struct {
+	list recencyList[K, V]
+}
+
+func newLRUEvictor[K comparable, V any]() *lruEvictor[K, V] {
+	return &lruEvictor[K, V]{}
+}
+
+func (e *lruEvictor[K, V]) Insert(w *wrapper[K, V]) {
+	e.list.pushFront(w)
+}
+
+func (e *lruEvictor[K, V]) Touch(w *wrapper[K, V]) {
+	e.list.moveToFront(w)
+}
+
+func (e *lruEvictor[K, V]) Remove(w *wrapper[K, V]) {
+	e.list.unlink(w)
+}
+
+func (e *lruEvictor[K, V]) EvictOne() *wrapper[K, V] {
+	w := e.list.tail
+	e.list.unlink(w)
+	return w
+}
+
+func (e *lruEvictor[K, V]) Len() int {
+	return e.list.length
+}
+
+func (e *lruEvictor[K, V]) TouchOnRead() bool {
+	return true
+}
+
+// WithLRUPolicy selects the least-recently-used eviction policy: the item
+// that was least recently inserted or accessed is evicted first.
+func WithLRUPolicy[K comparable, V any]() Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.evictor = newLRUEvictor[K, V]()
+	}
+}
+
+// sieveEvictor implements the SIEVE eviction policy (Zhang et al., NSDI'24):
+// a FIFO-ordered list plus a single "hand" pointer and a per-item visited
+// bit. Touch only sets visited; it never moves the item, so insertion order
+// is cheap to maintain. EvictOne walks backward from hand (or the tail if
+// hand is nil), clearing visited bits until it finds an unvisited item to evict.
+type sieveEvictor[K comparable, V any] struct {
+	list recencyList[K, V]
+	hand *wrapper[K, V]
+}
+
+func newSIEVEEvictor[K comparable, V any]() *sieveEvictor[K, V] {
+	return &sieveEvictor[K, V]{}
+}
+
+func (e *sieveEvictor[K, V]) Insert(w *wrapper[K, V]) {
+	w.visited = false
+	e.list.pushFront(w)
+}
+
+func (e *sieveEvictor[K, V]) Touch(w *wrapper[K, V]) {
+	w.visited = true
+}
+
+func (e *sieveEvictor[K, V]) Remove(w *wrapper[K, V]) {
+	if e.hand == w {
+		e.hand = w.prev
+	}
+	e.list.unlink(w)
+}
+
+func (e *sieveEvictor[K, V]) EvictOne() *wrapper[K, V] {
+	o := e.hand
+	if o == nil {
+		o = e.list.tail
+	}
+
+	for o.visited {
+		o.visited = false
+		o = o.prev
+		if o == nil {
+			o = e.list.tail
+		}
+	}
+
+	e.hand = o.prev
+	e.list.unlink(o)
+	return o
+}
+
+func (e *sieveEvictor[K, V]) Len() int {
+	return e.list.length
+}
+
+func (e *sieveEvictor[K, V]) TouchOnRead() bool {
+	return true
+}
+
+// WithSIEVEPolicy selects the SIEVE eviction policy, a low-overhead
+// approximation of LRU that scales better under scan-heavy workloads.
+func WithSIEVEPolicy[K comparable, V any]() Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.evictor = newSIEVEEvictor[K, V]()
+	}
+}
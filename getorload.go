@@ -0,0 +1,53 @@
+package heapcache
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// sfKey encodes a comparable key as a string for use as a singleflight key.
+// Common key types take a fast path; anything else falls back to fmt.Sprint.
+func sfKey[K comparable](key K) string {
+	switch k := any(key).(type) {
+	case string:
+		return k
+	case int:
+		return strconv.Itoa(k)
+	case int32:
+		return strconv.FormatInt(int64(k), 10)
+	case int64:
+		return strconv.FormatInt(k, 10)
+	case uint:
+		return strconv.FormatUint(uint64(k), 10)
+	case uint32:
+		return strconv.FormatUint(uint64(k), 10)
+	case uint64:
+		return strconv.FormatUint(k, 10)
+	default:
+		return fmt.Sprint(key)
+	}
+}
+
+// GetOrLoad gets a value by key. On a cache hit the value is returned
+// immediately. On a miss, loader is called exactly once per key across all
+// concurrently waiting callers (via singleflight), its result is Add-ed to
+// the cache, and handed to every waiter. A loader error is not cached.
+func (c *Cache[K, V]) GetOrLoad(key K, loader func() (V, error)) (V, error) {
+	if value, ok := c.Get(key); ok {
+		return value, nil
+	}
+
+	value, err, _ := c.sf.Do(sfKey(key), func() (interface{}, error) {
+		value, err := loader()
+		if err != nil {
+			return value, err
+		}
+		c.Add(key, value)
+		return value, nil
+	})
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	return value.(V), nil
+}
@@ -0,0 +1,75 @@
+package heapcache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_GetOrLoad_Hit(t *testing.T) {
+	c := New[string, *cacheItem](10, testLess)
+	c.Add("foo1", &cacheItem{"bar1", 1})
+
+	val, err := c.GetOrLoad("foo1", func() (*cacheItem, error) {
+		t.Fatal("loader must not be called on a hit")
+		return nil, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "bar1", val.Value)
+}
+
+func TestCache_GetOrLoad_Miss(t *testing.T) {
+	c := New[string, *cacheItem](10, testLess)
+
+	val, err := c.GetOrLoad("foo1", func() (*cacheItem, error) {
+		return &cacheItem{"bar1", 1}, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "bar1", val.Value)
+
+	cached, ok := c.Get("foo1")
+	assert.True(t, ok)
+	assert.Equal(t, val, cached)
+}
+
+func TestCache_GetOrLoad_Coalesces(t *testing.T) {
+	c := New[string, *cacheItem](10, testLess)
+
+	var calls int32
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			val, err := c.GetOrLoad("foo1", func() (*cacheItem, error) {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(20 * time.Millisecond)
+				return &cacheItem{"bar1", 1}, nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, "bar1", val.Value)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestCache_GetOrLoad_Error(t *testing.T) {
+	c := New[string, *cacheItem](10, testLess)
+
+	wantErr := errors.New("boom")
+	_, err := c.GetOrLoad("foo1", func() (*cacheItem, error) {
+		return nil, wantErr
+	})
+	assert.Equal(t, wantErr, err)
+	assert.False(t, c.All("foo1"))
+}
@@ -1,43 +1,85 @@
 package heapcache
 
-type (
-	itemsHeap struct {
-		less Less
-		Heap []*wrapper
-	}
-)
+import "container/heap"
+
+// Less reports whether a should be evicted before b. Items for which Less
+// returns true are considered lower priority and are evicted first.
+type Less[V any] func(a, b V) bool
+
+// itemsHeap is a container/heap.Interface over wrappers, ordered by less.
+type itemsHeap[K comparable, V any] struct {
+	less  Less[V]
+	items []*wrapper[K, V]
+}
 
-func newHeap(capacity int, less Less) *itemsHeap {
-	return &itemsHeap{
-		less: less,
-		Heap: make([]*wrapper, 0, capacity),
+func newItemsHeap[K comparable, V any](capacity int, less Less[V]) itemsHeap[K, V] {
+	return itemsHeap[K, V]{
+		less:  less,
+		items: make([]*wrapper[K, V], 0, capacity),
 	}
 }
 
-func (h *itemsHeap) Len() int {
-	return len(h.Heap)
+func (h *itemsHeap[K, V]) Len() int {
+	return len(h.items)
 }
 
-func (h *itemsHeap) Less(i, j int) bool {
-	return h.less(h.Heap[i].value, h.Heap[j].value)
+func (h *itemsHeap[K, V]) Less(i, j int) bool {
+	return h.less(h.items[i].value, h.items[j].value)
 }
 
-func (h *itemsHeap) Swap(i, j int) {
-	h.Heap[i], h.Heap[j] = h.Heap[j], h.Heap[i]
-	h.Heap[i].index = i
-	h.Heap[j].index = j
+func (h *itemsHeap[K, V]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
 }
 
-func (h *itemsHeap) Push(value interface{}) {
-	item := value.(*wrapper)
-	item.index = len(h.Heap)
-	h.Heap = append(h.Heap, item)
+func (h *itemsHeap[K, V]) Push(value interface{}) {
+	item := value.(*wrapper[K, V])
+	item.index = len(h.items)
+	h.items = append(h.items, item)
 }
 
-func (h *itemsHeap) Pop() interface{} {
-	n := len(h.Heap)
-	item := h.Heap[n-1]
+func (h *itemsHeap[K, V]) Pop() interface{} {
+	n := len(h.items)
+	item := h.items[n-1]
 	item.index = -1 // for safety
-	h.Heap = h.Heap[0 : n-1]
+	h.items = h.items[0 : n-1]
 	return item
 }
+
+// heapEvictor is the default evictor, implementing the Cache's original
+// priority-heap eviction policy on top of itemsHeap.
+type heapEvictor[K comparable, V any] struct {
+	h itemsHeap[K, V]
+}
+
+func newHeapEvictor[K comparable, V any](capacity int, less Less[V]) *heapEvictor[K, V] {
+	return &heapEvictor[K, V]{h: newItemsHeap[K, V](capacity, less)}
+}
+
+func (e *heapEvictor[K, V]) Insert(w *wrapper[K, V]) {
+	heap.Push(&e.h, w)
+}
+
+func (e *heapEvictor[K, V]) Touch(w *wrapper[K, V]) {
+	heap.Fix(&e.h, w.index)
+}
+
+func (e *heapEvictor[K, V]) Remove(w *wrapper[K, V]) {
+	if w.index < 0 {
+		return
+	}
+	heap.Remove(&e.h, w.index)
+}
+
+func (e *heapEvictor[K, V]) EvictOne() *wrapper[K, V] {
+	return heap.Pop(&e.h).(*wrapper[K, V])
+}
+
+func (e *heapEvictor[K, V]) Len() int {
+	return e.h.Len()
+}
+
+func (e *heapEvictor[K, V]) TouchOnRead() bool {
+	return false
+}
@@ -0,0 +1,168 @@
+// Package legacy provides the pre-generics heapcache API: an Item interface
+// with interface{} keys instead of Cache[K, V]. It is a thin wrapper around
+// github.com/turboezh/heapcache so existing callers can keep building while
+// they migrate to the generic API at their own pace.
+package legacy
+
+import (
+	"time"
+
+	"github.com/turboezh/heapcache"
+)
+
+type (
+	// Item is something that able to be added to cache.
+	Item interface {
+		// CacheKey return key of item in cache. It may be any key type (see https://golang.org/ref/spec#KeyType)
+		CacheKey() interface{}
+		// CacheLess determines priority if items in cache. Items with less priority will be evicted first.
+		CacheLess(interface{}) bool
+	}
+
+	// EvictionReason describes why an item left the cache.
+	EvictionReason = heapcache.EvictionReason
+
+	config struct {
+		ttl time.Duration
+	}
+
+	// Option configures optional Cache behaviour at construction time.
+	Option func(*config)
+
+	// Cache is a cache abstraction
+	Cache struct {
+		inner *heapcache.Cache[interface{}, Item]
+	}
+)
+
+const (
+	ReasonCapacity       = heapcache.ReasonCapacity
+	ReasonExpired        = heapcache.ReasonExpired
+	ReasonManual         = heapcache.ReasonManual
+	ReasonPurge          = heapcache.ReasonPurge
+	ReasonCapacityShrink = heapcache.ReasonCapacityShrink
+)
+
+func itemLess(a, b Item) bool {
+	return a.CacheLess(b)
+}
+
+// WithTTL sets a default TTL applied by Add. AddWithTTL overrides it on a
+// per-item basis.
+func WithTTL(ttl time.Duration) Option {
+	return func(cfg *config) {
+		cfg.ttl = ttl
+	}
+}
+
+// New creates a new Cache instance
+// Capacity allowed to be zero. In this case cache becomes dummy, 'Add' do nothing and items can't be stored in.
+func New(capacity int, opts ...Option) *Cache {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var innerOpts []heapcache.Option[interface{}, Item]
+	if cfg.ttl > 0 {
+		innerOpts = append(innerOpts, heapcache.WithTTL[interface{}, Item](cfg.ttl))
+	}
+
+	return &Cache{inner: heapcache.New[interface{}, Item](capacity, itemLess, innerOpts...)}
+}
+
+// Capacity returns capacity of cache
+func (c *Cache) Capacity() int {
+	return c.inner.Capacity()
+}
+
+// Add adds a `value` into a cache. If `key` already exists, `value` and `priority` will be overwritten.
+// `key` must be a KeyType (see https://golang.org/ref/spec#KeyType)
+func (c *Cache) Add(items ...Item) {
+	for _, item := range items {
+		c.inner.Add(item.CacheKey(), item)
+	}
+}
+
+// AddWithTTL adds a `value` into a cache with its own expiration, overriding any default TTL.
+// A zero or negative ttl means the item never expires.
+func (c *Cache) AddWithTTL(item Item, ttl time.Duration) {
+	c.inner.AddWithTTL(item.CacheKey(), item, ttl)
+}
+
+// Get gets a value by `key`
+func (c *Cache) Get(key interface{}) (interface{}, bool) {
+	return c.inner.Get(key)
+}
+
+// All checks if ALL `keys` exists
+func (c *Cache) All(keys ...interface{}) bool {
+	return c.inner.All(keys...)
+}
+
+// Any checks if ANY of `keys` exists
+func (c *Cache) Any(keys ...interface{}) bool {
+	return c.inner.Any(keys...)
+}
+
+// Remove removes values by keys
+// Returns number of actually removed items
+func (c *Cache) Remove(keys ...interface{}) int {
+	return c.inner.Remove(keys...)
+}
+
+// Len returns a number of items in cache
+func (c *Cache) Len() int {
+	return c.inner.Len()
+}
+
+// Purge removes all items
+func (c *Cache) Purge() {
+	c.inner.Purge()
+}
+
+// Evict removes `count` elements with lowest priority.
+func (c *Cache) Evict(count int) int {
+	return c.inner.Evict(count)
+}
+
+// ChangeCapacity change cache capacity by `delta`.
+// If `delta` is positive cache capacity will be expanded, if `delta` is negative, it will be shrunk.
+// Redundant items will be evicted.
+func (c *Cache) ChangeCapacity(delta int) {
+	c.inner.ChangeCapacity(delta)
+}
+
+// SetCapacity sets cache capacity.
+// Redundant items will be evicted.
+// Capacity will never be less than zero.
+func (c *Cache) SetCapacity(capacity int) {
+	c.inner.SetCapacity(capacity)
+}
+
+// Stop terminates the background expiration goroutine started by WithTTL or
+// AddWithTTL. It is a no-op if TTL support was never used.
+func (c *Cache) Stop() {
+	c.inner.Stop()
+}
+
+// OnInsertion subscribes fn to be called whenever an item is added or
+// overwritten. It returns an id that can later be passed to RemoveListener.
+func (c *Cache) OnInsertion(fn func(key, value interface{})) uint64 {
+	return c.inner.OnInsertion(func(key interface{}, value Item) {
+		fn(key, value)
+	})
+}
+
+// OnEviction subscribes fn to be called whenever an item leaves the cache.
+// It returns an id that can later be passed to RemoveListener.
+func (c *Cache) OnEviction(fn func(key, value interface{}, reason EvictionReason)) uint64 {
+	return c.inner.OnEviction(func(key interface{}, value Item, reason heapcache.EvictionReason) {
+		fn(key, value, reason)
+	})
+}
+
+// RemoveListener unsubscribes a listener previously registered via OnInsertion or OnEviction.
+func (c *Cache) RemoveListener(id uint64) {
+	c.inner.RemoveListener(id)
+}
@@ -0,0 +1,85 @@
+package legacy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type cacheItem struct {
+	Key      interface{}
+	Value    string
+	Priority int
+}
+
+func (i *cacheItem) CacheKey() interface{} {
+	return i.Key
+}
+
+func (i *cacheItem) CacheLess(other interface{}) bool {
+	return i.Priority < other.(*cacheItem).Priority
+}
+
+func TestCache_Add(t *testing.T) {
+	c := New(10)
+
+	foo1 := &cacheItem{"foo1", "bar1", 1}
+	c.Add(foo1)
+
+	val, ok := c.Get("foo1")
+	assert.True(t, ok)
+	assert.Equal(t, foo1, val)
+}
+
+func TestCache_Priority(t *testing.T) {
+	c := New(3)
+
+	c.Add(&cacheItem{"foo1", "bar1", 1})
+	c.Add(&cacheItem{"foo2", "bar2", 2})
+	c.Add(&cacheItem{"foo3", "bar3", 3})
+	c.Add(&cacheItem{"foo4", "bar4", 4})
+
+	assert.Equal(t, 3, c.Len())
+	assert.False(t, c.All("foo1"))
+	assert.True(t, c.All("foo4"))
+}
+
+func TestCache_Remove(t *testing.T) {
+	c := New(10)
+
+	c.Add(&cacheItem{"foo1", "bar1", 1})
+	assert.Equal(t, 1, c.Remove("foo1"))
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestCache_AddWithTTL(t *testing.T) {
+	c := New(10)
+	defer c.Stop()
+
+	c.AddWithTTL(&cacheItem{"foo1", "bar1", 1}, 20*time.Millisecond)
+
+	time.Sleep(100 * time.Millisecond)
+
+	_, ok := c.Get("foo1")
+	assert.False(t, ok)
+}
+
+func TestCache_OnEviction(t *testing.T) {
+	c := New(1)
+
+	done := make(chan EvictionReason, 1)
+	c.OnEviction(func(key, value interface{}, reason EvictionReason) {
+		done <- reason
+	})
+
+	c.Add(&cacheItem{"foo1", "bar1", 1})
+	c.Add(&cacheItem{"foo2", "bar2", 2})
+
+	select {
+	case reason := <-done:
+		assert.Equal(t, ReasonCapacity, reason)
+	case <-time.After(time.Second):
+		t.Fatal("eviction event was never fired")
+	}
+}
@@ -0,0 +1,64 @@
+package heapcache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_LRUPolicy_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := New[string, *cacheItem](2, testLess, WithLRUPolicy[string, *cacheItem]())
+
+	c.Add("foo1", &cacheItem{"bar1", 1})
+	c.Add("foo2", &cacheItem{"bar2", 2})
+
+	// touch foo1 so foo2 becomes the least recently used
+	_, _ = c.Get("foo1")
+
+	c.Add("foo3", &cacheItem{"bar3", 3})
+
+	assert.True(t, c.All("foo1", "foo3"))
+	assert.False(t, c.All("foo2"))
+}
+
+func TestCache_LRUPolicy_OverwriteCountsAsUse(t *testing.T) {
+	c := New[string, *cacheItem](2, testLess, WithLRUPolicy[string, *cacheItem]())
+
+	c.Add("foo1", &cacheItem{"bar1", 1})
+	c.Add("foo2", &cacheItem{"bar2", 2})
+	c.Add("foo1", &cacheItem{"bar1b", 1})
+
+	c.Add("foo3", &cacheItem{"bar3", 3})
+
+	assert.True(t, c.All("foo1", "foo3"))
+	assert.False(t, c.All("foo2"))
+}
+
+func TestCache_SIEVEPolicy_SparesVisitedItems(t *testing.T) {
+	c := New[string, *cacheItem](3, testLess, WithSIEVEPolicy[string, *cacheItem]())
+
+	c.Add("foo1", &cacheItem{"bar1", 1})
+	c.Add("foo2", &cacheItem{"bar2", 2})
+	c.Add("foo3", &cacheItem{"bar3", 3})
+
+	// mark the oldest two as visited; foo3 is left unvisited
+	_, _ = c.Get("foo1")
+	_, _ = c.Get("foo2")
+
+	c.Add("foo4", &cacheItem{"bar4", 4})
+
+	assert.False(t, c.All("foo3"))
+	assert.True(t, c.All("foo1", "foo2", "foo4"))
+}
+
+func TestCache_SIEVEPolicy_EvictsInInsertionOrderWhenUnvisited(t *testing.T) {
+	c := New[string, *cacheItem](2, testLess, WithSIEVEPolicy[string, *cacheItem]())
+
+	c.Add("foo1", &cacheItem{"bar1", 1})
+	c.Add("foo2", &cacheItem{"bar2", 2})
+
+	c.Add("foo3", &cacheItem{"bar3", 3})
+
+	assert.True(t, c.All("foo2", "foo3"))
+	assert.False(t, c.All("foo1"))
+}
@@ -0,0 +1,202 @@
+package heapcache
+
+import (
+	"container/heap"
+	"time"
+)
+
+// expirationHeap is a container/heap.Interface over wrappers, ordered by expiresAt.
+// It only ever holds wrappers that carry a non-zero expiresAt.
+type expirationHeap[K comparable, V any] struct {
+	items []*wrapper[K, V]
+}
+
+func newExpirationHeap[K comparable, V any](capacity int) *expirationHeap[K, V] {
+	return &expirationHeap[K, V]{items: make([]*wrapper[K, V], 0, capacity)}
+}
+
+func (h *expirationHeap[K, V]) Len() int {
+	return len(h.items)
+}
+
+func (h *expirationHeap[K, V]) Less(i, j int) bool {
+	return h.items[i].expiresAt.Before(h.items[j].expiresAt)
+}
+
+func (h *expirationHeap[K, V]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].expIndex = i
+	h.items[j].expIndex = j
+}
+
+func (h *expirationHeap[K, V]) Push(value interface{}) {
+	item := value.(*wrapper[K, V])
+	item.expIndex = len(h.items)
+	h.items = append(h.items, item)
+}
+
+func (h *expirationHeap[K, V]) Pop() interface{} {
+	n := len(h.items)
+	item := h.items[n-1]
+	item.expIndex = -1 // for safety
+	h.items = h.items[0 : n-1]
+	return item
+}
+
+// expired reports whether w has a TTL and it has passed.
+func (w *wrapper[K, V]) expired() bool {
+	return !w.expiresAt.IsZero() && time.Now().After(w.expiresAt)
+}
+
+// WithTTL sets a default TTL applied by Add. AddWithTTL overrides it on a
+// per-item basis. Passing a zero or negative ttl disables the default again.
+func WithTTL[K comparable, V any](ttl time.Duration) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.defaultTTL = ttl
+		if ttl > 0 {
+			c.ensureMonitor()
+		}
+	}
+}
+
+// ensureMonitor lazily starts the background expiration goroutine the first
+// time TTL support is actually needed.
+func (c *Cache[K, V]) ensureMonitor() {
+	c.monitorOn.Do(func() {
+		c.expHeap = newExpirationHeap[K, V](c.capacity)
+		c.timerCh = make(chan time.Duration, 1)
+		c.stopCh = make(chan struct{})
+		c.monitorRun = true
+		c.monitorWG.Add(1)
+		go c.monitor()
+	})
+}
+
+// setExpiry sets or clears w's expiry and keeps expHeap consistent.
+// caller must keep write lock
+func (c *Cache[K, V]) setExpiry(w *wrapper[K, V], expiresAt time.Time) {
+	if expiresAt.IsZero() {
+		if c.expHeap != nil && w.expIndex >= 0 {
+			heap.Remove(c.expHeap, w.expIndex)
+			c.notifyTimer()
+		}
+		w.expiresAt = time.Time{}
+		return
+	}
+
+	c.ensureMonitor()
+	w.expiresAt = expiresAt
+	if w.expIndex >= 0 {
+		heap.Fix(c.expHeap, w.expIndex)
+	} else {
+		heap.Push(c.expHeap, w)
+	}
+	c.notifyTimer()
+}
+
+// notifyTimer tells monitor to re-evaluate its wait after expHeap's head changed.
+// caller must keep write lock
+func (c *Cache[K, V]) notifyTimer() {
+	if c.timerCh == nil {
+		return
+	}
+
+	d := c.nextExpiryDuration()
+	select {
+	case c.timerCh <- d:
+	default:
+		select {
+		case <-c.timerCh:
+		default:
+		}
+		select {
+		case c.timerCh <- d:
+		default:
+		}
+	}
+}
+
+// caller must keep at least read lock
+func (c *Cache[K, V]) nextExpiryDuration() time.Duration {
+	if c.expHeap.Len() == 0 {
+		return 0
+	}
+	if d := time.Until(c.expHeap.items[0].expiresAt); d > 0 {
+		return d
+	}
+	// The head is already due. Returning 0 here would be indistinguishable
+	// from "heap empty" to monitor's d > 0 checks and the timer would never
+	// get (re)armed, leaving the due item unswept until something else
+	// happens to touch it. Clamp to the smallest positive duration instead so
+	// the timer fires right away.
+	return time.Nanosecond
+}
+
+// evictExpired removes every wrapper whose TTL has passed.
+// caller must keep write lock
+func (c *Cache[K, V]) evictExpired() {
+	now := time.Now()
+	for c.expHeap.Len() > 0 && !c.expHeap.items[0].expiresAt.After(now) {
+		w := heap.Pop(c.expHeap).(*wrapper[K, V])
+		delete(c.items, w.key)
+		c.evictor.Remove(w)
+		c.totalWeight -= w.weight
+		c.fireEviction(w.key, w.value, ReasonExpired)
+	}
+}
+
+// monitor sleeps until the earliest expiry and evicts it, resetting its wait
+// whenever timerCh signals that expHeap's head changed. It mirrors the
+// approach used by jellydator/ttlcache.
+func (c *Cache[K, V]) monitor() {
+	defer c.monitorWG.Done()
+
+	timer := time.NewTimer(time.Hour)
+	if !timer.Stop() {
+		<-timer.C
+	}
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case d := <-c.timerCh:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			if d > 0 {
+				timer.Reset(d)
+			}
+		case <-timer.C:
+			c.mutex.Lock()
+			c.evictExpired()
+			next := c.nextExpiryDuration()
+			c.mutex.Unlock()
+
+			if next > 0 {
+				timer.Reset(next)
+			}
+		}
+	}
+}
+
+// Stop terminates the background expiration goroutine started by WithTTL or
+// AddWithTTL, and waits for it to exit. It is a no-op if TTL support was
+// never used. Already-cached items are left untouched.
+func (c *Cache[K, V]) Stop() {
+	c.mutex.Lock()
+	running := c.monitorRun
+	c.monitorRun = false
+	c.mutex.Unlock()
+
+	if !running {
+		return
+	}
+
+	close(c.stopCh)
+	c.monitorWG.Wait()
+}
@@ -0,0 +1,101 @@
+package heapcache
+
+import (
+	"errors"
+	"time"
+)
+
+// Weigher computes the charge of a value for a Cache built with NewWithWeight.
+type Weigher[V any] func(value V) int64
+
+// ErrItemTooLarge is returned by TryAdd when a single item's weight exceeds
+// the Cache's MaxWeight, so it could never fit even after evicting everything else.
+var ErrItemTooLarge = errors.New("heapcache: item weight exceeds max weight")
+
+// NewWithWeight creates a new Cache that tracks capacity by a caller-supplied
+// weight (e.g. a blob's byte size) instead of item count. less still
+// determines eviction priority among items; weigher computes each item's
+// contribution to Weight().
+func NewWithWeight[K comparable, V any](maxWeight int64, less Less[V], weigher Weigher[V], opts ...Option[K, V]) *Cache[K, V] {
+	if maxWeight < 0 {
+		maxWeight = 0
+	}
+
+	c := &Cache[K, V]{
+		items:     make(map[K]*wrapper[K, V]),
+		weigher:   weigher,
+		maxWeight: maxWeight,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.evictor == nil {
+		c.evictor = newHeapEvictor[K, V](0, less)
+	}
+
+	return c
+}
+
+// Weight returns the sum of weights of items currently in cache.
+func (c *Cache[K, V]) Weight() int64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.totalWeight
+}
+
+// MaxWeight returns the maximum total weight a Cache built with NewWithWeight will hold.
+func (c *Cache[K, V]) MaxWeight() int64 {
+	return c.maxWeight
+}
+
+func (c *Cache[K, V]) addItemWeighted(key K, value V, ttl time.Duration) error {
+	weight := c.weigher(value)
+
+	if weight > c.maxWeight {
+		return ErrItemTooLarge
+	}
+
+	if c.maxWeight == 0 {
+		return nil
+	}
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if item, ok := c.items[key]; ok { // already exists
+		// Take item out of the evictor's bookkeeping first so the eviction
+		// loop below can never pick it as its own victim.
+		c.evictor.Remove(item)
+		c.totalWeight -= item.weight
+
+		for c.evictor.Len() > 0 && c.totalWeight+weight > c.maxWeight {
+			c.evict(1, ReasonCapacity)
+		}
+
+		item.value = value
+		item.weight = weight
+		c.evictor.Insert(item)
+		c.totalWeight += weight
+		c.setExpiry(item, expiresAt)
+		c.fireInsertion(key, value)
+		return nil
+	}
+
+	for c.evictor.Len() > 0 && c.totalWeight+weight > c.maxWeight {
+		c.evict(1, ReasonCapacity)
+	}
+
+	w := &wrapper[K, V]{key: key, value: value, weight: weight, expIndex: -1}
+
+	c.evictor.Insert(w)
+	c.items[w.key] = w
+	c.totalWeight += weight
+	c.setExpiry(w, expiresAt)
+	c.fireInsertion(key, value)
+	return nil
+}
@@ -0,0 +1,103 @@
+package heapcache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func blobWeigher(v *cacheItem) int64 {
+	return int64(v.Priority)
+}
+
+func TestCache_NewWithWeight(t *testing.T) {
+	c := NewWithWeight[string, *cacheItem](10, testLess, blobWeigher)
+
+	c.Add("foo1", &cacheItem{"bar1", 4}) // weight 4
+	c.Add("foo2", &cacheItem{"bar2", 5}) // weight 5, total 9
+
+	assert.True(t, c.All("foo1", "foo2"))
+	assert.Equal(t, int64(9), c.Weight())
+	assert.Equal(t, int64(10), c.MaxWeight())
+
+	// foo3 (weight 3) needs 3 more: evicts foo1 (lowest priority/weight) to fit.
+	c.Add("foo3", &cacheItem{"bar3", 3})
+
+	assert.True(t, c.All("foo2", "foo3"))
+	assert.False(t, c.All("foo1"))
+	assert.LessOrEqual(t, c.Weight(), int64(10))
+}
+
+func TestCache_NewWithWeight_Overwrite(t *testing.T) {
+	c := NewWithWeight[string, *cacheItem](10, testLess, blobWeigher)
+
+	c.Add("foo1", &cacheItem{"bar1", 4})
+	assert.Equal(t, int64(4), c.Weight())
+
+	c.Add("foo1", &cacheItem{"bar1b", 7})
+	assert.Equal(t, int64(7), c.Weight())
+
+	val, ok := c.Get("foo1")
+	assert.True(t, ok)
+	assert.Equal(t, "bar1b", val.Value)
+}
+
+func TestCache_NewWithWeight_OverwriteEvictsToFit(t *testing.T) {
+	c := NewWithWeight[string, *cacheItem](10, testLess, blobWeigher)
+
+	c.Add("foo1", &cacheItem{"bar1", 4})
+	c.Add("foo2", &cacheItem{"bar2", 2}) // lower priority, evicted first if room is needed
+	assert.Equal(t, int64(6), c.Weight())
+
+	// growing foo1 to weight 9 doesn't fit alongside foo2 (9+2 > 10): foo2 is evicted.
+	c.Add("foo1", &cacheItem{"bar1b", 9})
+
+	assert.True(t, c.All("foo1"))
+	assert.False(t, c.All("foo2"))
+	assert.Equal(t, int64(9), c.Weight())
+	assert.LessOrEqual(t, c.Weight(), c.MaxWeight())
+}
+
+func TestCache_NewWithWeight_OverwriteTooLarge(t *testing.T) {
+	c := NewWithWeight[string, *cacheItem](10, testLess, blobWeigher)
+
+	c.Add("foo1", &cacheItem{"bar1", 4})
+
+	err := c.TryAdd("foo1", &cacheItem{"bar1b", 20})
+	assert.ErrorIs(t, err, ErrItemTooLarge)
+
+	val, ok := c.Get("foo1")
+	assert.True(t, ok)
+	assert.Equal(t, "bar1", val.Value)
+	assert.Equal(t, int64(4), c.Weight())
+}
+
+func TestCache_TryAdd_ItemTooLarge(t *testing.T) {
+	c := NewWithWeight[string, *cacheItem](10, testLess, blobWeigher)
+
+	err := c.TryAdd("foo1", &cacheItem{"bar1", 20})
+	assert.ErrorIs(t, err, ErrItemTooLarge)
+	assert.False(t, c.All("foo1"))
+	assert.Equal(t, int64(0), c.Weight())
+}
+
+func TestCache_Add_ItemTooLarge_Silent(t *testing.T) {
+	c := NewWithWeight[string, *cacheItem](10, testLess, blobWeigher)
+
+	c.Add("foo1", &cacheItem{"bar1", 20})
+	assert.False(t, c.All("foo1"))
+	assert.Equal(t, int64(0), c.Weight())
+}
+
+func TestCache_NewWithWeight_Remove(t *testing.T) {
+	c := NewWithWeight[string, *cacheItem](10, testLess, blobWeigher)
+
+	c.Add("foo1", &cacheItem{"bar1", 4})
+	c.Add("foo2", &cacheItem{"bar2", 5})
+
+	assert.Equal(t, 1, c.Remove("foo1"))
+	assert.Equal(t, int64(5), c.Weight())
+
+	c.Purge()
+	assert.Equal(t, int64(0), c.Weight())
+}